@@ -0,0 +1,773 @@
+// Package xmlrpc implements a minimal XML-RPC codec: decoding
+// <methodResponse> documents into plain Go structs via reflection, and
+// encoding method calls and responses from Go values.
+package xmlrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// dateTimeLayout is the format used by XML-RPC's <dateTime.iso8601>,
+// which omits the "-" and ":" separators ISO 8601 normally allows.
+const dateTimeLayout = "20060102T15:04:05"
+
+// apacheExtensionsNS is the namespace apache's xmlrpc-c and ws-xmlrpc
+// implementations use to qualify their <nil/> and <i8> extension
+// elements, which otherwise share a local name with the unqualified
+// elements of the same spelling (see rpcValue.UnmarshalXML).
+const apacheExtensionsNS = "http://ws.apache.org/xmlrpc/namespaces/extensions"
+
+const (
+	errFormatInvalidFieldType   = "xmlrpc: cannot decode %s value into struct field of kind %s"
+	errFormatFieldCountMismatch = "xmlrpc: response has %d param(s) but target struct has %d field(s)"
+)
+
+var errDecodeTargetNotStruct = errors.New("xmlrpc: decode target must be a non-nil pointer to a struct")
+
+// fieldTag is the parsed form of a `xmlrpc:"..."` struct tag, following
+// the convention shared by encoding/xml and encoding/json: a name
+// (defaulting to the heuristic member-name transform when empty),
+// optionally followed by comma-separated options.
+type fieldTag struct {
+	Name      string
+	OmitEmpty bool
+	Any       bool
+	Skip      bool
+}
+
+func parseFieldTag(f reflect.StructField) fieldTag {
+	raw, ok := f.Tag.Lookup("xmlrpc")
+	if !ok {
+		return fieldTag{}
+	}
+	if raw == "-" {
+		return fieldTag{Skip: true}
+	}
+	parts := strings.Split(raw, ",")
+	ft := fieldTag{Name: parts[0]}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			ft.OmitEmpty = true
+		case "any":
+			ft.Any = true
+		}
+	}
+	return ft
+}
+
+// nonSkippedFieldIndices returns, in declaration order, the indices of
+// t's exported fields that aren't tagged xmlrpc:"-".
+func nonSkippedFieldIndices(t reflect.Type) []int {
+	var idx []int
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if parseFieldTag(sf).Skip {
+			continue
+		}
+		idx = append(idx, i)
+	}
+	return idx
+}
+
+// StdDecoder decodes a raw <methodResponse> document into a Go struct
+// via reflection.
+type StdDecoder struct {
+	// Options controls support for non-standard XML-RPC extensions. A
+	// nil Options uses DefaultDecoderOptions.
+	Options *DecoderOptions
+
+	typeDecoders map[reflect.Type]func([]byte, reflect.Value) error
+}
+
+// RegisterTypeDecoder installs fn as the decoding logic for fields of
+// type t, taking priority over StdDecoder's own reflect-based decoding.
+// It is meant for stdlib or third-party types the caller can't attach an
+// Unmarshaler implementation to directly.
+func (d *StdDecoder) RegisterTypeDecoder(t reflect.Type, fn func([]byte, reflect.Value) error) {
+	if d.typeDecoders == nil {
+		d.typeDecoders = map[reflect.Type]func([]byte, reflect.Value) error{}
+	}
+	d.typeDecoders[t] = fn
+}
+
+// tryUnmarshaler hands raw off to field's Unmarshaler implementation, if
+// it (or a pointer to it) has one, allocating the field if it's a nil
+// pointer. It reports whether an Unmarshaler was found and invoked.
+func tryUnmarshaler(field reflect.Value, raw []byte) (bool, error) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			if !field.CanSet() {
+				return false, nil
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		if u, ok := field.Interface().(Unmarshaler); ok {
+			return true, u.UnmarshalXMLRPC(&Value{raw: raw})
+		}
+		return false, nil
+	}
+	if field.CanAddr() {
+		if u, ok := field.Addr().Interface().(Unmarshaler); ok {
+			return true, u.UnmarshalXMLRPC(&Value{raw: raw})
+		}
+	}
+	return false, nil
+}
+
+// xmlMethodResponse mirrors the top-level shape of an XML-RPC
+// methodResponse document, leaving the actual value parsing to rpcValue.
+type xmlMethodResponse struct {
+	XMLName xml.Name         `xml:"methodResponse"`
+	Params  *xmlParams       `xml:"params"`
+	Fault   *xmlFaultWrapper `xml:"fault"`
+}
+
+type xmlParams struct {
+	Param []xmlParam `xml:"param"`
+}
+
+type xmlParam struct {
+	Value rpcValue `xml:"value"`
+}
+
+type xmlFaultWrapper struct {
+	Value rpcValue `xml:"value"`
+}
+
+// rpcValue captures an XML-RPC <value> element. Exactly one of the typed
+// fields is populated for a well-formed document; an untyped <value>
+// (bare string shorthand) falls back to CharData.
+//
+// rpcValue implements xml.Unmarshaler itself rather than relying on
+// struct tags, because apache's <nil/> and <i8> extension elements
+// share a local name with the base-spec elements of the same spelling
+// and differ only by namespace; encoding/xml's struct-tag matching
+// (`xml:"nil"`) matches by local name alone and can't tell them apart,
+// so dispatch on the element's namespace is done explicitly below.
+type rpcValue struct {
+	String   *string
+	Int      *string
+	I4       *string
+	Boolean  *string
+	Double   *string
+	Array    *rpcArray
+	Struct   *rpcStruct
+	Base64   *string
+	DateTime *string
+	CharData string
+	Raw      []byte
+
+	// Extensions beyond the base XML-RPC spec, gated by DecoderOptions.
+	Nil            *struct{}
+	I8             *string
+	ApacheNil      *struct{}
+	ApacheI8       *string
+	ApacheDateTime *string
+}
+
+type rpcArray struct {
+	Values []rpcValue `xml:"data>value"`
+}
+
+type rpcStruct struct {
+	Members []rpcMember `xml:"member"`
+}
+
+type rpcMember struct {
+	Name  string   `xml:"name"`
+	Value rpcValue `xml:"value"`
+}
+
+// tokenSliceReader replays a pre-read slice of tokens as an xml.TokenReader,
+// letting a sub-tree collected during UnmarshalXML be decoded a second time
+// (e.g. via DecodeElement into rpcArray/rpcStruct) without re-parsing bytes.
+type tokenSliceReader struct {
+	toks []xml.Token
+	pos  int
+}
+
+func (r *tokenSliceReader) Token() (xml.Token, error) {
+	if r.pos >= len(r.toks) {
+		return nil, io.EOF
+	}
+	t := r.toks[r.pos]
+	r.pos++
+	return t, nil
+}
+
+// reencodeTokens re-serializes a token sequence captured mid-stream back
+// into self-contained XML bytes, for use as rpcValue.Raw.
+func reencodeTokens(toks []xml.Token) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	for _, t := range toks {
+		if err := enc.EncodeToken(t); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalXML decodes a <value> element's contents into v. It reads the
+// raw token stream directly instead of delegating to struct-tag matching
+// so that elements sharing a local name across namespaces (apache's
+// <nil/> and <i8> extensions) can be told apart by their resolved
+// namespace rather than conflated.
+func (v *rpcValue) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var all []xml.Token
+	var chardata strings.Builder
+	var child *xml.StartElement
+	var childToks []xml.Token
+	depth := 0
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		tok = xml.CopyToken(tok)
+
+		if _, ok := tok.(xml.EndElement); ok && depth == 0 {
+			break
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && child == nil {
+				c := t
+				child = &c
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 {
+				chardata.Write(t)
+			}
+		}
+		all = append(all, tok)
+		if child != nil {
+			childToks = append(childToks, tok)
+		}
+	}
+
+	raw, err := reencodeTokens(all)
+	if err != nil {
+		return err
+	}
+	v.Raw = raw
+	v.CharData = chardata.String()
+
+	if child == nil {
+		return nil
+	}
+
+	// childToks holds every token from child's opening tag through its
+	// matching close. sub must read that opening tag itself (rather than
+	// being handed the already-read *StartElement, the way a single
+	// decoder normally is) so that its own open-element stack is primed
+	// to recognize the matching close when DecodeElement reaches it.
+	sub := xml.NewTokenDecoder(&tokenSliceReader{toks: childToks})
+	subStart, err := sub.Token()
+	if err != nil {
+		return err
+	}
+	subStartEl := subStart.(xml.StartElement)
+
+	switch {
+	case child.Name.Space == "" && child.Name.Local == "array":
+		v.Array = &rpcArray{}
+		return sub.DecodeElement(v.Array, &subStartEl)
+	case child.Name.Space == "" && child.Name.Local == "struct":
+		v.Struct = &rpcStruct{}
+		return sub.DecodeElement(v.Struct, &subStartEl)
+	case child.Name.Space == "" && child.Name.Local == "nil":
+		v.Nil = &struct{}{}
+		return nil
+	case child.Name.Space == apacheExtensionsNS && child.Name.Local == "nil":
+		v.ApacheNil = &struct{}{}
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "i8":
+		s := childText(childToks)
+		v.I8 = &s
+		return nil
+	case child.Name.Space == apacheExtensionsNS && child.Name.Local == "i8":
+		s := childText(childToks)
+		v.ApacheI8 = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "string":
+		s := childText(childToks)
+		v.String = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "int":
+		s := childText(childToks)
+		v.Int = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "i4":
+		s := childText(childToks)
+		v.I4 = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "boolean":
+		s := childText(childToks)
+		v.Boolean = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "double":
+		s := childText(childToks)
+		v.Double = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "base64":
+		s := childText(childToks)
+		v.Base64 = &s
+		return nil
+	case child.Name.Space == "" && child.Name.Local == "dateTime.iso8601":
+		s := childText(childToks)
+		v.DateTime = &s
+		return nil
+	case child.Name.Space == apacheExtensionsNS && child.Name.Local == "dateTime":
+		s := childText(childToks)
+		v.ApacheDateTime = &s
+		return nil
+	default:
+		return nil
+	}
+}
+
+// childText concatenates the character data directly inside a captured
+// child element. toks starts with the child's opening tag, which is
+// skipped; tokens after the child's matching close are ignored.
+func childText(toks []xml.Token) string {
+	var s strings.Builder
+	depth := 1
+	for _, t := range toks[1:] {
+		switch tt := t.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				return s.String()
+			}
+		case xml.CharData:
+			if depth == 1 {
+				s.Write(tt)
+			}
+		}
+	}
+	return s.String()
+}
+
+// DecodeRaw decodes a raw XML-RPC methodResponse document into v, which
+// must be a non-nil pointer to a struct. Each exported field of the
+// struct not tagged xmlrpc:"-" receives the corresponding positional
+// <param> of the response, in declaration order. If the response is a <fault>, DecodeRaw returns
+// a *Fault describing it.
+func (d *StdDecoder) DecodeRaw(data []byte, v interface{}) error {
+	var resp xmlMethodResponse
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+
+	if resp.Fault != nil {
+		return decodeFault(resp.Fault.Value)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errDecodeTargetNotStruct
+	}
+	elem := rv.Elem()
+
+	var params []xmlParam
+	if resp.Params != nil {
+		params = resp.Params.Param
+	}
+
+	if err := fieldsMustEqual(elem.Interface(), len(params)); err != nil {
+		return err
+	}
+
+	idx := nonSkippedFieldIndices(elem.Type())
+	for i, p := range params {
+		if err := d.decodeValue(elem.Field(idx[i]), p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeFault(val rpcValue) error {
+	f := &Fault{}
+	if val.Struct == nil {
+		return f
+	}
+	for _, m := range val.Struct.Members {
+		switch m.Name {
+		case "faultCode":
+			if n, ok := memberInt(m.Value); ok {
+				f.Code = n
+			}
+		case "faultString":
+			f.String = memberString(m.Value)
+		}
+	}
+	return f
+}
+
+func memberInt(v rpcValue) (int, bool) {
+	switch {
+	case v.Int != nil:
+		n, err := strconv.Atoi(strings.TrimSpace(*v.Int))
+		return n, err == nil
+	case v.I4 != nil:
+		n, err := strconv.Atoi(strings.TrimSpace(*v.I4))
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func memberString(v rpcValue) string {
+	if v.String != nil {
+		return *v.String
+	}
+	return strings.TrimSpace(v.CharData)
+}
+
+// decodeValue decodes a single XML-RPC value into field, which must be
+// addressable and settable. A target whose type (or a pointer to it)
+// implements Unmarshaler, or that has a decoder registered via
+// RegisterTypeDecoder, takes priority over the built-in reflect-based
+// decoding below.
+func (d *StdDecoder) decodeValue(field reflect.Value, val rpcValue) error {
+	if ok, err := tryUnmarshaler(field, val.Raw); ok {
+		return err
+	}
+	if fn, ok := d.typeDecoders[field.Type()]; ok {
+		return fn(val.Raw, field)
+	}
+
+	opts := d.options()
+
+	switch {
+	case val.Array != nil:
+		return d.decodeArray(field, val.Array)
+	case val.Struct != nil:
+		return d.decodeStruct(field, val.Struct)
+	case opts.AllowNil && val.Nil != nil:
+		return decodeNil(field)
+	case opts.AllowNil && opts.AllowApacheExtensions && val.ApacheNil != nil:
+		return decodeNil(field)
+	case val.Int != nil:
+		return decodeInt(field, *val.Int)
+	case val.I4 != nil:
+		return decodeInt(field, *val.I4)
+	case opts.AllowI8 && val.I8 != nil:
+		return decodeI8(field, *val.I8)
+	case opts.AllowI8 && opts.AllowApacheExtensions && val.ApacheI8 != nil:
+		return decodeI8(field, *val.ApacheI8)
+	case val.Boolean != nil:
+		return decodeBool(field, *val.Boolean)
+	case val.Double != nil:
+		return decodeDouble(field, *val.Double)
+	case val.Base64 != nil:
+		return decodeBase64(field, *val.Base64)
+	case val.DateTime != nil:
+		return decodeDateTime(field, *val.DateTime)
+	case opts.AllowApacheExtensions && val.ApacheDateTime != nil:
+		return decodeDateTime(field, *val.ApacheDateTime)
+	case val.String != nil:
+		return decodeString(field, *val.String)
+	default:
+		return decodeString(field, strings.TrimSpace(val.CharData))
+	}
+}
+
+func (d *StdDecoder) decodeArray(field reflect.Value, arr *rpcArray) error {
+	switch field.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(field.Type(), len(arr.Values), len(arr.Values))
+		for i, v := range arr.Values {
+			if err := d.decodeValue(s.Index(i), v); err != nil {
+				return err
+			}
+		}
+		field.Set(s)
+		return nil
+	case reflect.Interface:
+		s := make([]interface{}, len(arr.Values))
+		sv := reflect.ValueOf(s)
+		for i, v := range arr.Values {
+			if err := d.decodeValue(sv.Index(i), v); err != nil {
+				return err
+			}
+		}
+		field.Set(sv)
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "slice", field.Kind().String())
+	}
+}
+
+func (d *StdDecoder) decodeStruct(field reflect.Value, st *rpcStruct) error {
+	switch field.Kind() {
+	case reflect.Struct:
+		t := field.Type()
+		tagged := map[string]int{}
+		anyIdx := -1
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			ft := parseFieldTag(sf)
+			switch {
+			case ft.Skip:
+				continue
+			case ft.Any:
+				anyIdx = i
+			case ft.Name != "":
+				tagged[ft.Name] = i
+			}
+		}
+
+		for _, m := range st.Members {
+			if i, ok := tagged[m.Name]; ok {
+				if err := d.decodeValue(field.Field(i), m.Value); err != nil {
+					return err
+				}
+				continue
+			}
+
+			goName := structMemberToFieldName(m.Name)
+			if sf, ok := t.FieldByName(goName); ok {
+				ft := parseFieldTag(sf)
+				if !ft.Skip && ft.Name == "" {
+					if err := d.decodeValue(field.FieldByName(goName), m.Value); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+
+			if anyIdx >= 0 {
+				if err := d.decodeAny(field.Field(anyIdx), m.Name, m.Value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Interface:
+		mp := make(map[string]interface{}, len(st.Members))
+		for _, m := range st.Members {
+			var v interface{}
+			if err := d.decodeValue(reflect.ValueOf(&v).Elem(), m.Value); err != nil {
+				return err
+			}
+			mp[m.Name] = v
+		}
+		field.Set(reflect.ValueOf(mp))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "struct", field.Kind().String())
+	}
+}
+
+func decodeInt(field reflect.Value, s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(int64(n))
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(n))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "int", field.Kind().String())
+	}
+}
+
+func decodeBool(field reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	b := s == "1" || strings.EqualFold(s, "true")
+	switch field.Kind() {
+	case reflect.Bool:
+		field.SetBool(b)
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(b))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "boolean", field.Kind().String())
+	}
+}
+
+func decodeDouble(field reflect.Value, s string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(f)
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(f))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "double", field.Kind().String())
+	}
+}
+
+// decodeNil decodes an apache/xmlrpc-c <nil/> extension element into a
+// nil pointer or interface field.
+func decodeNil(field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "nil", field.Kind().String())
+	}
+}
+
+// decodeI8 decodes an apache/xmlrpc-c <i8> 64-bit integer extension.
+func decodeI8(field reflect.Value, s string) error {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(n)
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(n))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "i8", field.Kind().String())
+	}
+}
+
+func decodeBase64(field reflect.Value, s string) error {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	switch {
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		field.SetBytes(data)
+		return nil
+	case field.Kind() == reflect.Interface:
+		field.Set(reflect.ValueOf(data))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "base64", field.Kind().String())
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func decodeDateTime(field reflect.Value, s string) error {
+	t, err := time.Parse(dateTimeLayout, strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	switch {
+	case field.Kind() == reflect.Struct && field.Type() == timeType:
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case field.Kind() == reflect.Interface:
+		field.Set(reflect.ValueOf(t))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "dateTime.iso8601", field.Kind().String())
+	}
+}
+
+// decodeAny feeds a struct member that matched no field, tagged or
+// heuristic, into the xmlrpc:"...,any" catch-all sink, which must be a
+// map[string]interface{} or a []struct{ Name string; Value interface{} }.
+func (d *StdDecoder) decodeAny(sink reflect.Value, name string, val rpcValue) error {
+	var v interface{}
+	if err := d.decodeValue(reflect.ValueOf(&v).Elem(), val); err != nil {
+		return err
+	}
+
+	switch sink.Kind() {
+	case reflect.Map:
+		if sink.IsNil() {
+			sink.Set(reflect.MakeMap(sink.Type()))
+		}
+		sink.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(v))
+		return nil
+	case reflect.Slice:
+		entry := reflect.New(sink.Type().Elem()).Elem()
+		entry.FieldByName("Name").SetString(name)
+		entry.FieldByName("Value").Set(reflect.ValueOf(v))
+		sink.Set(reflect.Append(sink, entry))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "struct", sink.Kind().String())
+	}
+}
+
+func decodeString(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(s))
+		return nil
+	default:
+		return fmt.Errorf(errFormatInvalidFieldType, "string", field.Kind().String())
+	}
+}
+
+// fieldsMustEqual returns an error if the number of exported, non-skipped
+// (xmlrpc:"-") fields on the struct v does not equal expect.
+func fieldsMustEqual(v interface{}, expect int) error {
+	n := len(nonSkippedFieldIndices(reflect.TypeOf(v)))
+	if n != expect {
+		return fmt.Errorf(errFormatFieldCountMismatch, expect, n)
+	}
+	return nil
+}
+
+// structMemberToFieldName converts an XML-RPC struct member name
+// (lowerCamelCase or snake_case) into the PascalCase Go field name it is
+// expected to bind to. It is only consulted when a field has no explicit
+// xmlrpc:"..." name tag.
+func structMemberToFieldName(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		r := []rune(p)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package xmlrpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdDecoder_DecodeRaw_Nil(t *testing.T) {
+	dec := &StdDecoder{}
+
+	name := "not nil yet"
+	target := &struct {
+		Name *string
+	}{Name: &name}
+	assert.NoError(t, dec.DecodeRaw(loadTestFile(t, "response_nil.xml"), target))
+	assert.Nil(t, target.Name)
+
+	badTarget := &struct {
+		Name string
+	}{}
+	err := dec.DecodeRaw(loadTestFile(t, "response_nil.xml"), badTarget)
+	assert.Equal(t, fmt.Errorf(errFormatInvalidFieldType, "nil", "string"), err)
+}
+
+func TestStdDecoder_DecodeRaw_I8(t *testing.T) {
+	dec := &StdDecoder{}
+	target := &struct {
+		Big int64
+	}{}
+	assert.NoError(t, dec.DecodeRaw(loadTestFile(t, "response_i8.xml"), target))
+	assert.Equal(t, int64(9223372036854775807), target.Big)
+}
+
+func TestStdDecoder_DecodeRaw_ApacheExtensions(t *testing.T) {
+	dec := &StdDecoder{Options: &DecoderOptions{AllowNil: true, AllowI8: true, AllowApacheExtensions: true}}
+	target := &struct {
+		Nilly *string
+		Big   int64
+		When  time.Time
+	}{}
+	assert.NoError(t, dec.DecodeRaw(loadTestFile(t, "response_apache_ext.xml"), target))
+	assert.Nil(t, target.Nilly)
+	assert.Equal(t, int64(42), target.Big)
+	assert.True(t, target.When.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)))
+}
+
+func TestStdDecoder_DecodeRaw_ApacheExtensionsDisabledByDefault(t *testing.T) {
+	dec := &StdDecoder{}
+	target := &struct {
+		Big int64
+	}{}
+	err := dec.DecodeRaw(loadTestFile(t, "response_apache_i8_only.xml"), target)
+	assert.Equal(t, fmt.Errorf(errFormatInvalidFieldType, "string", "int64"), err)
+}
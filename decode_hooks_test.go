@@ -0,0 +1,70 @@
+package xmlrpc
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// customTime decodes an XML-RPC <string> using a layout the server
+// happens to emit instead of the standard library's dateTime.iso8601
+// handling, exercising the Unmarshaler hook.
+type customTime struct {
+	time.Time
+}
+
+func (c *customTime) UnmarshalXMLRPC(v *Value) error {
+	var inner struct {
+		Chardata string `xml:",chardata"`
+	}
+	if err := xml.Unmarshal(v.Raw(), &inner); err != nil {
+		return err
+	}
+	t, err := time.Parse("2006-01-02 15:04:05", strings.TrimSpace(inner.Chardata))
+	if err != nil {
+		return err
+	}
+	c.Time = t
+	return nil
+}
+
+func TestStdDecoder_DecodeRaw_Unmarshaler(t *testing.T) {
+	target := &struct {
+		When customTime
+	}{}
+
+	dec := &StdDecoder{}
+	err := dec.DecodeRaw(loadTestFile(t, "response_custom_time.xml"), target)
+	assert.NoError(t, err)
+	assert.True(t, target.When.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)))
+}
+
+func TestStdDecoder_RegisterTypeDecoder(t *testing.T) {
+	dec := &StdDecoder{}
+	dec.RegisterTypeDecoder(reflect.TypeOf(time.Duration(0)), func(raw []byte, target reflect.Value) error {
+		var inner struct {
+			Chardata string `xml:",chardata"`
+		}
+		if err := xml.Unmarshal(raw, &inner); err != nil {
+			return err
+		}
+		ns, err := strconv.ParseInt(strings.TrimSpace(inner.Chardata), 10, 64)
+		if err != nil {
+			return err
+		}
+		target.SetInt(ns)
+		return nil
+	})
+
+	target := &struct {
+		Elapsed time.Duration
+	}{}
+	err := dec.DecodeRaw(loadTestFile(t, "response_i8_duration.xml"), target)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, target.Elapsed)
+}
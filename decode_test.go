@@ -111,6 +111,37 @@ func TestStdDecoder_DecodeRaw(t *testing.T) {
 			expect: nil,
 			err:    fmt.Errorf(errFormatInvalidFieldType, "struct", "string"),
 		},
+		{
+			name:     "struct response - tags",
+			testFile: "response_struct_tags.xml",
+			v: &struct {
+				Struct struct {
+					FooBar string                 `xmlrpc:"foo-bar"`
+					Hidden string                 `xmlrpc:"-"`
+					Extra  map[string]interface{} `xmlrpc:",any"`
+				}
+			}{},
+			expect: &struct {
+				Struct struct {
+					FooBar string                 `xmlrpc:"foo-bar"`
+					Hidden string                 `xmlrpc:"-"`
+					Extra  map[string]interface{} `xmlrpc:",any"`
+				}
+			}{
+				Struct: struct {
+					FooBar string                 `xmlrpc:"foo-bar"`
+					Hidden string                 `xmlrpc:"-"`
+					Extra  map[string]interface{} `xmlrpc:",any"`
+				}{
+					FooBar: "renamed",
+					Hidden: "",
+					Extra: map[string]interface{}{
+						"hidden":      "should-be-skipped",
+						"extra_stuff": "caught",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -183,6 +214,17 @@ func Test_fieldsMustEqual(t *testing.T) {
 			},
 			expect: 1,
 		},
+		{
+			name: "field tagged with xmlrpc dash is not counted",
+			input: struct {
+				Pub    int
+				Hidden int `xmlrpc:"-"`
+			}{
+				Pub:    3,
+				Hidden: 4,
+			},
+			expect: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,326 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MethodCall is the encode-side counterpart to a decoded methodResponse:
+// a method name plus its positional arguments, shared by clients
+// building a request and servers replaying one.
+type MethodCall struct {
+	Name   string
+	Params []interface{}
+}
+
+var errEncodeValueNotStruct = errors.New("xmlrpc: encoded response value must be a struct or a pointer to one")
+
+// StdEncoder encodes XML-RPC documents, mirroring StdDecoder via
+// reflect-driven traversal of structs/maps/slices.
+type StdEncoder struct{}
+
+// EncodeMethodCall encodes name and args as a <methodCall> document.
+func (e *StdEncoder) EncodeMethodCall(name string, args ...interface{}) ([]byte, error) {
+	return e.encodeCall(&MethodCall{Name: name, Params: args})
+}
+
+func (e *StdEncoder) encodeCall(c *MethodCall) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(&buf, []byte(c.Name)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params>")
+	for _, p := range c.Params {
+		buf.WriteString("<param>")
+		if err := encodeValueTo(&buf, reflect.ValueOf(p)); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+// EncodeMethodResponse encodes v as a <methodResponse> document. If v is
+// a *Fault, it is encoded as a <fault>. Otherwise v must be a struct (or
+// pointer to one) whose exported, non-skipped (xmlrpc:"-") fields become
+// the response's positional params, in declaration order — the inverse
+// of StdDecoder.DecodeRaw.
+func (e *StdEncoder) EncodeMethodResponse(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodResponse>")
+
+	if f, ok := v.(*Fault); ok {
+		if err := encodeFaultBody(&buf, f); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</methodResponse>")
+		return buf.Bytes(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errEncodeValueNotStruct
+	}
+
+	buf.WriteString("<params>")
+	for _, i := range nonSkippedFieldIndices(rv.Type()) {
+		buf.WriteString("<param>")
+		if err := encodeValueTo(&buf, rv.Field(i)); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodResponse>")
+	return buf.Bytes(), nil
+}
+
+// EncodeFault encodes f as a standalone <methodResponse><fault> document.
+func (e *StdEncoder) EncodeFault(f *Fault) ([]byte, error) {
+	return e.EncodeMethodResponse(f)
+}
+
+func encodeFaultBody(buf *bytes.Buffer, f *Fault) error {
+	buf.WriteString("<fault><value><struct>")
+	buf.WriteString("<member><name>faultCode</name><value><int>")
+	buf.WriteString(strconv.Itoa(f.Code))
+	buf.WriteString("</int></value></member>")
+	buf.WriteString("<member><name>faultString</name><value><string>")
+	if err := xml.EscapeText(buf, []byte(f.String)); err != nil {
+		return err
+	}
+	buf.WriteString("</string></value></member>")
+	buf.WriteString("</struct></value></fault>")
+	return nil
+}
+
+// encodeValueTo writes v as a single <value>...</value> element.
+func encodeValueTo(buf *bytes.Buffer, v reflect.Value) error {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		buf.WriteString("<value><nil/></value>")
+		return nil
+	}
+
+	if m, ok := marshalerFor(v); ok {
+		raw, err := m.MarshalXMLRPC()
+		if err != nil {
+			return err
+		}
+		buf.WriteString("<value>")
+		buf.Write(raw)
+		buf.WriteString("</value>")
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			buf.WriteString("<value><nil/></value>")
+			return nil
+		}
+		return encodeValueTo(buf, v.Elem())
+	case reflect.String:
+		buf.WriteString("<value><string>")
+		if err := xml.EscapeText(buf, []byte(v.String())); err != nil {
+			return err
+		}
+		buf.WriteString("</string></value>")
+		return nil
+	case reflect.Bool:
+		buf.WriteString("<value><boolean>")
+		if v.Bool() {
+			buf.WriteString("1")
+		} else {
+			buf.WriteString("0")
+		}
+		buf.WriteString("</boolean></value>")
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString("<value><int>")
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+		buf.WriteString("</int></value>")
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString("<value><int>")
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+		buf.WriteString("</int></value>")
+		return nil
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString("<value><double>")
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+		buf.WriteString("</double></value>")
+		return nil
+	case reflect.Struct:
+		if v.Type() == timeType {
+			buf.WriteString("<value><dateTime.iso8601>")
+			buf.WriteString(v.Interface().(time.Time).Format(dateTimeLayout))
+			buf.WriteString("</dateTime.iso8601></value>")
+			return nil
+		}
+		buf.WriteString("<value><struct>")
+		if err := encodeStructMembers(buf, v); err != nil {
+			return err
+		}
+		buf.WriteString("</struct></value>")
+		return nil
+	case reflect.Map:
+		buf.WriteString("<value><struct>")
+		if err := encodeMapMembers(buf, v); err != nil {
+			return err
+		}
+		buf.WriteString("</struct></value>")
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			buf.WriteString("<value><base64>")
+			buf.WriteString(base64.StdEncoding.EncodeToString(v.Bytes()))
+			buf.WriteString("</base64></value>")
+			return nil
+		}
+		buf.WriteString("<value><array><data>")
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeValueTo(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array></value>")
+		return nil
+	default:
+		return fmt.Errorf("xmlrpc: cannot encode value of kind %s", v.Kind())
+	}
+}
+
+func marshalerFor(v reflect.Value) (Marshaler, bool) {
+	if v.CanInterface() {
+		if m, ok := v.Interface().(Marshaler); ok {
+			return m, true
+		}
+	}
+	if v.CanAddr() {
+		if m, ok := v.Addr().Interface().(Marshaler); ok {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// encodeStructMembers writes v's exported fields as <member> elements,
+// honoring the same xmlrpc:"name,omitempty"/"-"/"...,any" tags the
+// decoder understands.
+func encodeStructMembers(buf *bytes.Buffer, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		ft := parseFieldTag(sf)
+		if ft.Skip {
+			continue
+		}
+		fv := v.Field(i)
+		if ft.Any {
+			if err := encodeAnySink(buf, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if ft.OmitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		name := ft.Name
+		if name == "" {
+			name = sf.Name
+		}
+		if err := encodeMember(buf, name, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeAnySink writes the contents of an xmlrpc:"...,any" catch-all
+// sink field as additional <member> elements.
+func encodeAnySink(buf *bytes.Buffer, sink reflect.Value) error {
+	switch sink.Kind() {
+	case reflect.Map:
+		return encodeMapMembers(buf, sink)
+	case reflect.Slice:
+		for i := 0; i < sink.Len(); i++ {
+			entry := sink.Index(i)
+			name := entry.FieldByName("Name").String()
+			if err := encodeMember(buf, name, entry.FieldByName("Value")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("xmlrpc: xmlrpc:\",any\" sink must be a map or slice, got %s", sink.Kind())
+	}
+}
+
+// encodeMapMembers writes m's entries as <member> elements in
+// deterministic (sorted-key) order.
+func encodeMapMembers(buf *bytes.Buffer, m reflect.Value) error {
+	keys := m.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := encodeMember(buf, name, m.MapIndex(reflect.ValueOf(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeMember(buf *bytes.Buffer, name string, v reflect.Value) error {
+	buf.WriteString("<member><name>")
+	if err := xml.EscapeText(buf, []byte(name)); err != nil {
+		return err
+	}
+	buf.WriteString("</name>")
+	if err := encodeValueTo(buf, v); err != nil {
+		return err
+	}
+	buf.WriteString("</member>")
+	return nil
+}
+
+// isEmptyValue mirrors encoding/json's omitempty semantics.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
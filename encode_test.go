@@ -0,0 +1,100 @@
+package xmlrpc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStdEncoder_RoundTrip decodes the simple, array and struct testdata
+// fixtures, re-encodes the result, decodes the re-encoded bytes again,
+// and checks the two decoded values match — a golden-file comparison
+// that's insensitive to whitespace/formatting differences in the raw
+// XML.
+func TestStdEncoder_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		testFile string
+		v        interface{}
+	}{
+		{
+			name:     "simple response",
+			testFile: "response_simple.xml",
+			v: &struct {
+				Param string
+				Int   int
+			}{},
+		},
+		{
+			name:     "array response",
+			testFile: "response_array.xml",
+			v: &struct {
+				Ints []int
+			}{},
+		},
+		{
+			name:     "struct response",
+			testFile: "response_struct.xml",
+			v: &struct {
+				Struct struct {
+					Foo          string
+					Baz          int
+					WoBleBobble  bool
+					WoBleBobble2 int
+				}
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := &StdDecoder{}
+			enc := &StdEncoder{}
+
+			if err := dec.DecodeRaw(loadTestFile(t, tt.testFile), tt.v); err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := enc.EncodeMethodResponse(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			roundTripped := reflect.New(reflect.TypeOf(tt.v).Elem()).Interface()
+			if err := dec.DecodeRaw(encoded, roundTripped); err != nil {
+				t.Fatal(err)
+			}
+			assert.EqualValues(t, tt.v, roundTripped)
+		})
+	}
+}
+
+func TestStdEncoder_EncodeMethodResponse_Fault(t *testing.T) {
+	enc := &StdEncoder{}
+	encoded, err := enc.EncodeMethodResponse(&Fault{Code: 4, String: "Too many parameters."})
+	assert.NoError(t, err)
+
+	dec := &StdDecoder{}
+	err = dec.DecodeRaw(encoded, &struct{}{})
+	assert.Error(t, err)
+
+	fT := &Fault{}
+	assert.True(t, errors.As(err, &fT))
+	assert.EqualValues(t, &Fault{Code: 4, String: "Too many parameters."}, fT)
+}
+
+func TestStdEncoder_EncodeMethodCall(t *testing.T) {
+	enc := &StdEncoder{}
+	encoded, err := enc.EncodeMethodCall("system.echo", 1, "two", true)
+	assert.NoError(t, err)
+
+	s := string(encoded)
+	assert.True(t, strings.Contains(s, "<methodName>system.echo</methodName>"))
+	assert.Equal(t, 3, strings.Count(s, "<param>"))
+	assert.True(t, strings.Contains(s, "<int>1</int>"))
+	assert.True(t, strings.Contains(s, "<string>two</string>"))
+	assert.True(t, strings.Contains(s, "<boolean>1</boolean>"))
+}
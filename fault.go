@@ -0,0 +1,16 @@
+package xmlrpc
+
+import "fmt"
+
+// Fault represents an XML-RPC <fault> response, as returned by a server
+// when a method call cannot be completed. It implements the error
+// interface so it can be returned (and unwrapped via errors.As) directly
+// from StdDecoder.DecodeRaw.
+type Fault struct {
+	Code   int
+	String string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("xmlrpc fault %d: %s", f.Code, f.String)
+}
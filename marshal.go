@@ -0,0 +1,31 @@
+package xmlrpc
+
+// Value wraps the raw, unparsed contents of the XML-RPC <value> element
+// for a single parameter or struct member. It is handed to Unmarshaler
+// implementations so they can apply custom decoding logic (non-standard
+// time layouts, big.Int, UUIDs, domain enums, ...) instead of going
+// through StdDecoder's reflect-driven field matching.
+type Value struct {
+	raw []byte
+}
+
+// Raw returns the raw XML inside the <value> element: its type tag and
+// contents (e.g. "<dateTime.iso8601>20060102T15:04:05</dateTime.iso8601>"),
+// without the enclosing <value> tags.
+func (v *Value) Raw() []byte {
+	return v.raw
+}
+
+// Unmarshaler is implemented by types that decode their own XML-RPC
+// representation. StdDecoder.DecodeRaw checks whether a target field's
+// type, or a pointer to it, implements Unmarshaler before falling back
+// to its own reflect-based decoding.
+type Unmarshaler interface {
+	UnmarshalXMLRPC(value *Value) error
+}
+
+// Marshaler is implemented by types that encode their own XML-RPC
+// representation, mirroring Unmarshaler for the encoding side.
+type Marshaler interface {
+	MarshalXMLRPC() ([]byte, error)
+}
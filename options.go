@@ -0,0 +1,32 @@
+package xmlrpc
+
+// DecoderOptions toggles StdDecoder's support for XML-RPC extensions
+// that go beyond the base spec but are widely deployed in the wild.
+type DecoderOptions struct {
+	// AllowNil decodes <nil/> into a nil pointer or interface field.
+	// When false, <nil/> is not recognized and the value is decoded as
+	// an empty untyped string. Defaults to true.
+	AllowNil bool
+	// AllowI8 decodes the apache/xmlrpc-c <i8> 64-bit integer extension.
+	// Defaults to true.
+	AllowI8 bool
+	// AllowApacheExtensions additionally recognizes <ex:nil/>, <ex:i8>,
+	// and <ex:dateTime> under the
+	// http://ws.apache.org/xmlrpc/namespaces/extensions namespace.
+	// Defaults to false.
+	AllowApacheExtensions bool
+}
+
+// DefaultDecoderOptions returns the options StdDecoder uses when its
+// Options field is left nil: nil and i8 support on, namespaced apache
+// variants off.
+func DefaultDecoderOptions() DecoderOptions {
+	return DecoderOptions{AllowNil: true, AllowI8: true}
+}
+
+func (d *StdDecoder) options() DecoderOptions {
+	if d.Options != nil {
+		return *d.Options
+	}
+	return DefaultDecoderOptions()
+}
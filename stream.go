@@ -0,0 +1,189 @@
+package xmlrpc
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+)
+
+var errStreamTargetNotPointer = errors.New("xmlrpc: decode target must be a non-nil pointer")
+
+// StreamDecoder pulls a <methodResponse> apart one token at a time
+// instead of materializing the whole document, so that a response
+// carrying a very large <array> can be consumed without holding every
+// element in memory at once. It reuses StdDecoder's scalar-decoding
+// logic for each individual <value> it reads.
+type StreamDecoder struct {
+	xd      *xml.Decoder
+	started bool
+
+	// Decoder supplies the scalar/struct decoding behavior applied to
+	// every value pulled from the stream: its RegisterTypeDecoder hooks
+	// and Options are honored exactly as they would be for a
+	// whole-document StdDecoder.DecodeRaw call. Defaults to a zero-value
+	// *StdDecoder; replace it before the first NextParam/Array call to
+	// customize decoding.
+	Decoder *StdDecoder
+}
+
+// NewStreamDecoder returns a StreamDecoder reading a methodResponse
+// document from r.
+func NewStreamDecoder(r io.Reader) *StreamDecoder {
+	return &StreamDecoder{xd: xml.NewDecoder(r), Decoder: &StdDecoder{}}
+}
+
+// NextParam advances to the next <param> and returns a cursor positioned
+// to decode its value. It returns io.EOF once the response's params are
+// exhausted. If the response is a <fault>, NextParam returns a *Fault
+// error on its first call, exactly as StdDecoder.DecodeRaw does.
+func (s *StreamDecoder) NextParam() (*ParamCursor, error) {
+	if err := s.ensureStarted(); err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, err := s.xd.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "param" {
+				return &ParamCursor{sd: s}, nil
+			}
+		case xml.EndElement:
+			if el.Name.Local == "params" {
+				return nil, io.EOF
+			}
+		}
+	}
+}
+
+// ensureStarted skips leading tokens up to <params>, or decodes and
+// returns a *Fault if the response is a <fault> instead.
+func (s *StreamDecoder) ensureStarted() error {
+	if s.started {
+		return nil
+	}
+	for {
+		tok, err := s.xd.Token()
+		if err != nil {
+			return err
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch el.Name.Local {
+		case "params":
+			s.started = true
+			return nil
+		case "fault":
+			var val rpcValue
+			if err := s.nextValue(&val); err != nil {
+				return err
+			}
+			s.started = true
+			return decodeFault(val)
+		}
+	}
+}
+
+// nextValue scans forward for the next <value> start element and decodes
+// it, and everything it contains, into val in one shot. It is used for
+// scalars, structs, and fault values, which aren't large enough to
+// warrant element-at-a-time consumption.
+func (s *StreamDecoder) nextValue(val *rpcValue) error {
+	for {
+		tok, err := s.xd.Token()
+		if err != nil {
+			return err
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if el.Name.Local == "value" {
+			return s.xd.DecodeElement(val, &el)
+		}
+	}
+}
+
+// ParamCursor decodes a single <param>'s value, either all at once via
+// Decode or, for an <array>, element at a time via Array/ArrayCursor.
+type ParamCursor struct {
+	sd *StreamDecoder
+}
+
+// Decode fully decodes this param's value into v, which must be a
+// non-nil pointer. For large arrays, prefer Array instead.
+func (p *ParamCursor) Decode(v interface{}) error {
+	var val rpcValue
+	if err := p.sd.nextValue(&val); err != nil {
+		return err
+	}
+	return decodeInto(p.sd.Decoder, v, val)
+}
+
+// Array positions the cursor at the start of this param's <array> and
+// returns an ArrayCursor for consuming its elements one at a time,
+// without buffering the rest of the array in memory.
+func (p *ParamCursor) Array() (*ArrayCursor, error) {
+	for {
+		tok, err := p.sd.xd.Token()
+		if err != nil {
+			return nil, err
+		}
+		if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "data" {
+			return &ArrayCursor{sd: p.sd}, nil
+		}
+	}
+}
+
+// ArrayCursor pulls the elements of a streamed <array> one at a time.
+type ArrayCursor struct {
+	sd *StreamDecoder
+}
+
+// Next decodes the array's next element into v, which must be a non-nil
+// pointer. It returns false, nil once the array is exhausted. Callers
+// may stop calling Next at any point to abort early.
+func (a *ArrayCursor) Next(v interface{}) (bool, error) {
+	for {
+		tok, err := a.sd.xd.Token()
+		if err != nil {
+			return false, err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local != "value" {
+				continue
+			}
+			var val rpcValue
+			if err := a.sd.xd.DecodeElement(&val, &el); err != nil {
+				return false, err
+			}
+			if err := decodeInto(a.sd.Decoder, v, val); err != nil {
+				return false, err
+			}
+			return true, nil
+		case xml.EndElement:
+			if el.Name.Local == "data" {
+				return false, nil
+			}
+		}
+	}
+}
+
+// decodeInto decodes val into *v using dec's reflect-based
+// scalar/struct/array decoding, shared between the streaming and
+// whole-document decode paths so a caller's RegisterTypeDecoder hooks
+// and DecoderOptions apply consistently either way.
+func decodeInto(dec *StdDecoder, v interface{}, val rpcValue) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errStreamTargetNotPointer
+	}
+	return dec.decodeValue(rv.Elem(), val)
+}
@@ -0,0 +1,121 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamDecoder_NextParam(t *testing.T) {
+	sd := NewStreamDecoder(bytes.NewReader(loadTestFile(t, "response_stream_mixed.xml")))
+
+	p1, err := sd.NextParam()
+	assert.NoError(t, err)
+	var n int
+	assert.NoError(t, p1.Decode(&n))
+	assert.Equal(t, 7, n)
+
+	p2, err := sd.NextParam()
+	assert.NoError(t, err)
+	var when time.Time
+	assert.NoError(t, p2.Decode(&when))
+	assert.True(t, when.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)))
+
+	p3, err := sd.NextParam()
+	assert.NoError(t, err)
+	var raw []byte
+	assert.NoError(t, p3.Decode(&raw))
+	assert.Equal(t, "hello", string(raw))
+
+	p4, err := sd.NextParam()
+	assert.NoError(t, err)
+	arr, err := p4.Array()
+	assert.NoError(t, err)
+
+	var got []int
+	for {
+		var v int
+		ok, err := arr.Next(&v)
+		assert.NoError(t, err)
+		if !ok {
+			break
+		}
+		got = append(got, v)
+		if len(got) == 2 {
+			// Abort early: the rest of this (possibly huge) array is
+			// never materialized.
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, got)
+
+	_, err = sd.NextParam()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestStreamDecoder_Fault(t *testing.T) {
+	sd := NewStreamDecoder(bytes.NewReader(loadTestFile(t, "response_fault.xml")))
+
+	_, err := sd.NextParam()
+	assert.Error(t, err)
+
+	fT := &Fault{}
+	assert.True(t, errors.As(err, &fT))
+	assert.EqualValues(t, &Fault{
+		Code:   4,
+		String: "Too many parameters.",
+	}, fT)
+}
+
+// TestStreamDecoder_HonorsDecoder exercises a RegisterTypeDecoder hook on
+// a replacement Decoder through the streaming path, to make sure it's
+// honored per-value the same way StdDecoder.DecodeRaw would.
+func TestStreamDecoder_HonorsDecoder(t *testing.T) {
+	dec := &StdDecoder{}
+	dec.RegisterTypeDecoder(reflect.TypeOf(time.Duration(0)), func(raw []byte, target reflect.Value) error {
+		var inner struct {
+			Chardata string `xml:",chardata"`
+		}
+		if err := xml.Unmarshal(raw, &inner); err != nil {
+			return err
+		}
+		ns, err := strconv.ParseInt(strings.TrimSpace(inner.Chardata), 10, 64)
+		if err != nil {
+			return err
+		}
+		target.SetInt(ns)
+		return nil
+	})
+
+	sd := NewStreamDecoder(bytes.NewReader(loadTestFile(t, "response_i8_duration.xml")))
+	sd.Decoder = dec
+
+	p, err := sd.NextParam()
+	assert.NoError(t, err)
+	var elapsed time.Duration
+	assert.NoError(t, p.Decode(&elapsed))
+	assert.Equal(t, 5*time.Second, elapsed)
+}
+
+// TestStreamDecoder_DefaultOptionsRejectApacheExtensions confirms that a
+// StreamDecoder left at its default Decoder rejects apache-namespaced
+// extension values exactly as StdDecoder.DecodeRaw does, rather than
+// silently accepting them because it never consulted DecoderOptions.
+func TestStreamDecoder_DefaultOptionsRejectApacheExtensions(t *testing.T) {
+	sd := NewStreamDecoder(bytes.NewReader(loadTestFile(t, "response_apache_i8_only.xml")))
+
+	p, err := sd.NextParam()
+	assert.NoError(t, err)
+	var n int64
+	err = p.Decode(&n)
+	assert.Equal(t, fmt.Errorf(errFormatInvalidFieldType, "string", "int64"), err)
+}